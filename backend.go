@@ -0,0 +1,68 @@
+package dbg
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Record is the structured form of a single log call, handed to every
+// registered Backend. Stack is only populated for E/F/Panic.
+type Record struct {
+	Time  time.Time
+	Level Level
+	Label string
+	Tag   Tag
+	Msg   string
+	Args  []interface{}
+	Stack string
+}
+
+// Backend receives every Record produced by D/V/I/W/E/WTF. Implementations
+// must be safe for concurrent use.
+type Backend interface {
+	Log(r Record)
+}
+
+var (
+	backendsMu sync.RWMutex
+	backends   = []Backend{NewTextBackend(os.Stderr)}
+)
+
+// SetBackend replaces all registered backends with b, discarding the default
+// text backend.
+func SetBackend(b Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends = []Backend{b}
+}
+
+// AddBackend registers an additional backend; records are delivered to every
+// registered backend, in registration order.
+func AddBackend(b Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends = append(backends, b)
+}
+
+// emit builds a Record and hands it to every registered backend.
+func emit(level Level, label string, tag Tag, stack string, format string, args ...interface{}) {
+	backendsMu.RLock()
+	bs := backends
+	backendsMu.RUnlock()
+
+	args = redactArgs(args)
+	r := Record{
+		Time:  time.Now(),
+		Level: level,
+		Label: label,
+		Tag:   tag,
+		Msg:   fmt.Sprintf(format, args...),
+		Args:  args,
+		Stack: stack,
+	}
+	for _, b := range bs {
+		b.Log(r)
+	}
+}
@@ -0,0 +1,66 @@
+package dbg
+
+import "testing"
+
+// fakeBackend is a minimal Backend that just stores Records, used to verify
+// dispatch/fan-out without depending on Recorder.
+type fakeBackend struct {
+	records []Record
+}
+
+func (f *fakeBackend) Log(r Record) {
+	f.records = append(f.records, r)
+}
+
+// TestAddBackendFanOut verifies that AddBackend delivers every Record to
+// every registered backend, not just the last one installed.
+func TestAddBackendFanOut(t *testing.T) {
+	saved := backends
+	t.Cleanup(func() {
+		backendsMu.Lock()
+		backends = saved
+		backendsMu.Unlock()
+	})
+
+	first := &fakeBackend{}
+	second := &fakeBackend{}
+	SetBackend(first)
+	AddBackend(second)
+
+	I("backend-fanout", "hello")
+
+	if len(first.records) != 1 {
+		t.Errorf("want 1 record delivered to the first backend, got %d", len(first.records))
+	}
+	if len(second.records) != 1 {
+		t.Errorf("want 1 record delivered to the second backend, got %d", len(second.records))
+	}
+	if len(first.records) == 1 && len(second.records) == 1 && first.records[0].Msg != second.records[0].Msg {
+		t.Errorf("both backends should receive the same record, got %q and %q", first.records[0].Msg, second.records[0].Msg)
+	}
+}
+
+// TestSetBackendReplaces verifies that SetBackend discards any previously
+// registered backends instead of appending to them.
+func TestSetBackendReplaces(t *testing.T) {
+	saved := backends
+	t.Cleanup(func() {
+		backendsMu.Lock()
+		backends = saved
+		backendsMu.Unlock()
+	})
+
+	first := &fakeBackend{}
+	second := &fakeBackend{}
+	SetBackend(first)
+	SetBackend(second)
+
+	I("backend-replace", "hello")
+
+	if len(first.records) != 0 {
+		t.Errorf("SetBackend should have discarded the first backend, got %d records", len(first.records))
+	}
+	if len(second.records) != 1 {
+		t.Errorf("want 1 record delivered to the replacement backend, got %d", len(second.records))
+	}
+}
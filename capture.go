@@ -0,0 +1,80 @@
+package dbg
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Recorder is a Backend that buffers Records in memory instead of writing
+// them anywhere, so tests can assert on what was logged. Obtain one with
+// CaptureForTest.
+type Recorder struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	records []Record
+}
+
+// CaptureForTest installs a Recorder as the package's only backend for the
+// duration of t, restoring the previous backends on cleanup. Use it to
+// verify that a code path actually emitted an E/W/... call, without
+// depending on stderr output.
+func CaptureForTest(t *testing.T) *Recorder {
+	t.Helper()
+
+	rec := &Recorder{t: t}
+	prev := backends
+
+	backendsMu.Lock()
+	backends = []Backend{rec}
+	backendsMu.Unlock()
+
+	t.Cleanup(func() {
+		backendsMu.Lock()
+		backends = prev
+		backendsMu.Unlock()
+	})
+
+	return rec
+}
+
+// Log implements Backend.
+func (r *Recorder) Log(rec Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+}
+
+// Records returns a copy of every Record captured so far.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Record, len(r.records))
+	copy(out, r.records)
+	return out
+}
+
+// Count returns the number of captured Records at level l.
+func (r *Recorder) Count(l Level) int {
+	n := 0
+	for _, rec := range r.Records() {
+		if rec.Level == l {
+			n++
+		}
+	}
+	return n
+}
+
+// AssertContains fails the test unless a Record at level l, tag, whose
+// message contains substr, was captured.
+func (r *Recorder) AssertContains(l Level, tag Tag, substr string) bool {
+	r.t.Helper()
+	for _, rec := range r.Records() {
+		if rec.Level == l && rec.Tag == tag && strings.Contains(rec.Msg, substr) {
+			return true
+		}
+	}
+	r.t.Errorf("dbg: no %s/%s record containing %q was captured", l, tag, substr)
+	return false
+}
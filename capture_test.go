@@ -0,0 +1,57 @@
+package dbg
+
+import "testing"
+
+// TestRecorderCount verifies that Count tallies captured Records per level,
+// independently of any other level also being logged.
+func TestRecorderCount(t *testing.T) {
+	defer SetLevel(CurrentLevel())
+	SetLevel(LevelVerbose)
+
+	rec := CaptureForTest(t)
+	W("capture", "low disk space")
+	E("capture", "write failed")
+	E("capture", "write failed again")
+
+	if got := rec.Count(LevelWarn); got != 1 {
+		t.Errorf("want 1 WARN record, got %d", got)
+	}
+	if got := rec.Count(LevelError); got != 2 {
+		t.Errorf("want 2 ERROR records, got %d", got)
+	}
+	if got := rec.Count(LevelInfo); got != 0 {
+		t.Errorf("want 0 INFO records, got %d", got)
+	}
+}
+
+// TestRecorderAssertContains verifies that AssertContains matches on level,
+// tag and message substring together, not any one alone.
+func TestRecorderAssertContains(t *testing.T) {
+	defer SetLevel(CurrentLevel())
+	SetLevel(LevelVerbose)
+
+	rec := CaptureForTest(t)
+	I("capture", "user %s logged in", "alice")
+
+	if !rec.AssertContains(LevelInfo, "capture", "alice logged in") {
+		t.Errorf("AssertContains should have found the matching record")
+	}
+}
+
+// TestRecorderAssertContainsFails verifies that AssertContains reports a
+// failure (via t.Errorf on the captured *testing.T) when no record matches.
+func TestRecorderAssertContainsFails(t *testing.T) {
+	defer SetLevel(CurrentLevel())
+	SetLevel(LevelVerbose)
+
+	inner := &testing.T{}
+	rec := CaptureForTest(inner)
+	I("capture", "nothing to see here")
+
+	if rec.AssertContains(LevelError, "capture", "nothing to see here") {
+		t.Errorf("AssertContains should not match a different level")
+	}
+	if !inner.Failed() {
+		t.Errorf("AssertContains should have failed the inner *testing.T")
+	}
+}
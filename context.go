@@ -0,0 +1,138 @@
+package dbg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type ctxKey struct{}
+
+// Field is a single request-scoped key/value attached to a context via
+// NewContext, e.g. a request ID, user ID or trace ID.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// NewContext returns a child of ctx carrying fields in addition to any
+// already attached to ctx, to be automatically included in every log call
+// made through WithContext(ctx).
+func NewContext(ctx context.Context, fields ...Field) context.Context {
+	merged := append(append([]Field{}, fieldsFromContext(ctx)...), fields...)
+	return context.WithValue(ctx, ctxKey{}, merged)
+}
+
+func fieldsFromContext(ctx context.Context) []Field {
+	fields, _ := ctx.Value(ctxKey{}).([]Field)
+	return fields
+}
+
+// Logger logs against a fixed context, automatically appending any fields
+// attached via NewContext (e.g. a correlation ID) to every message it logs.
+type Logger struct {
+	ctx context.Context
+}
+
+// WithContext returns a Logger whose D/V/I/W/E/WTF calls append the fields
+// attached to ctx via NewContext to every message.
+func WithContext(ctx context.Context) Logger {
+	return Logger{ctx: ctx}
+}
+
+// withFields appends ctx's fields to format/args as trailing " key=value"
+// pairs, leaving format/args untouched if ctx carries no fields.
+func (lg Logger) withFields(format string, args []interface{}) (string, []interface{}) {
+	fields := fieldsFromContext(lg.ctx)
+	if len(fields) == 0 {
+		return format, args
+	}
+	for _, f := range fields {
+		format += " " + f.Key + "=%v"
+		args = append(args, f.Value)
+	}
+	return format, args
+}
+
+// D logs a debug-level message, see D.
+func (lg Logger) D(tag Tag, format string, args ...interface{}) {
+	format, args = lg.withFields(format, args)
+	D(tag, format, args...)
+}
+
+// V mirrors the package-level V, gating a message at verbosity level n,
+// with ctx's fields appended when it fires.
+func (lg Logger) V(n Level) ctxVerbose {
+	return ctxVerbose{v: V(n), lg: lg}
+}
+
+// I logs an info-level message, see I.
+func (lg Logger) I(tag Tag, format string, args ...interface{}) int64 {
+	format, args = lg.withFields(format, args)
+	return I(tag, format, args...)
+}
+
+// W logs a warning, see W.
+func (lg Logger) W(tag Tag, format string, args ...interface{}) int64 {
+	format, args = lg.withFields(format, args)
+	return W(tag, format, args...)
+}
+
+// E logs an error with stacktrace, see E. It calls E's skip-aware
+// implementation directly so the captured stack starts at Logger.E's
+// caller rather than at Logger.E itself.
+func (lg Logger) E(tag Tag, format string, args ...interface{}) int64 {
+	format, args = lg.withFields(format, args)
+	return logError(1, tag, format, args...)
+}
+
+// WTF logs a "what a terrible failure", see WTF.
+func (lg Logger) WTF(tag Tag, format string, args ...interface{}) int64 {
+	format, args = lg.withFields(format, args)
+	return WTF(tag, format, args...)
+}
+
+// F logs a fatal-level message, see F.
+func (lg Logger) F(tag Tag, format string, args ...interface{}) {
+	format, args = lg.withFields(format, args)
+	fatal(1, tag, format, args...)
+}
+
+// Panic logs a fatal-level message then panics, see Panic.
+func (lg Logger) Panic(tag Tag, format string, args ...interface{}) {
+	format, args = lg.withFields(format, args)
+	panicLog(1, tag, format, args...)
+}
+
+// ctxVerbose is the context-aware counterpart of Verbose, returned by
+// Logger.V.
+type ctxVerbose struct {
+	v  Verbose
+	lg Logger
+}
+
+// D logs a message at cv's verbosity level if tag's effective level allows
+// it, with the Logger's context fields appended.
+func (cv ctxVerbose) D(tag Tag, format string, args ...interface{}) {
+	if !enabled(tag, Level(cv.v)) {
+		return
+	}
+	format, args = cv.lg.withFields(format, args)
+	cv.v.D(tag, format, args...)
+}
+
+// CorrelationIDHeader is the HTTP header GetRequest populates with a
+// correlation ID when the incoming request doesn't already carry one.
+const CorrelationIDHeader = "X-Correlation-Id"
+
+// ensureCorrelationID returns r's correlation ID, generating and setting one
+// on r.Header if it doesn't already have one.
+func ensureCorrelationID(r *http.Request) string {
+	if id := r.Header.Get(CorrelationIDHeader); id != "" {
+		return id
+	}
+	id := fmt.Sprintf("%x", time.Now().UnixNano())
+	r.Header.Set(CorrelationIDHeader, id)
+	return id
+}
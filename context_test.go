@@ -0,0 +1,33 @@
+package dbg
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// logViaLogger is a named call site so the test can assert it - not dbg's
+// own frames - is where the captured stack trace starts.
+func logViaLogger(lg Logger) {
+	lg.E("test", "boom")
+}
+
+// TestLoggerStackCaptureSkipsOwnFrames verifies that Logger.E's captured
+// stack trace starts at the real call site, not at Logger.E or logError.
+func TestLoggerStackCaptureSkipsOwnFrames(t *testing.T) {
+	rec := CaptureForTest(t)
+	logViaLogger(WithContext(context.Background()))
+
+	recs := rec.Records()
+	if len(recs) != 1 {
+		t.Fatalf("want 1 record, got %d", len(recs))
+	}
+
+	stack := recs[0].Stack
+	if strings.Contains(stack, "Logger.E") || strings.Contains(stack, "logError") {
+		t.Errorf("stack trace should skip dbg's own frames, got:\n%s", stack)
+	}
+	if !strings.Contains(stack, "logViaLogger") {
+		t.Errorf("stack trace should start at the real call site, got:\n%s", stack)
+	}
+}
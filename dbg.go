@@ -5,9 +5,7 @@ package dbg
 
 import (
 	"fmt"
-	"log"
 	"net/http"
-	godbg "runtime/debug"
 	"strconv"
 	"time"
 )
@@ -30,22 +28,6 @@ const KCYN = "\x1B[36m"
 const KWHT = "\x1B[37m"
 const KRESET = "\033[0m"
 
-/* const Debugging can be set in production without any security risks, it enables debugging log-output.
- */
-const Debugging debug = true
-
-/* const Develop SHOULD NEVER EVER be set in Prod, it is used to disable security-relevant features in the using library
- */
-const Develop = true
-const v verbose = true
-const i info = true
-const l lg = true
-
-type debug bool
-type verbose bool
-type info bool
-type lg bool
-
 type Tag string
 
 // http://stackoverflow.com/a/25458067
@@ -64,79 +46,68 @@ VERBOSE – Very detailed information, intended only for development. You might
 
 // func D prints a debug message - development only
 func D(tag Tag, format string, args ...interface{}) {
-	Debugging.P(KNRM+"DEBUG", tag, format, args...)
-}
-
-// func V prints a verbose message - development only (importance below debug, e.g. for big variable prints)
-func V(tag Tag, format string, args ...interface{}) {
-	v.P(KFNT+"VERBOSE", tag, format, args...)
+	if !enabled(tag, LevelDebug) {
+		return
+	}
+	emit(LevelDebug, "DEBUG", tag, "", format, args...)
 }
 
 // func I prints a info message - will be in production mode, e.g. for registration finished, map uploaded etc.
 func I(tag Tag, format string, args ...interface{}) int64 {
 	timeKey := time.Now().UnixNano()
-	i.P(KGRN+"INFO", tag, strconv.FormatInt(timeKey, 10)+" -- "+format, args...)
+	if !enabled(tag, LevelInfo) {
+		return timeKey
+	}
+	emit(LevelInfo, "INFO", tag, "", strconv.FormatInt(timeKey, 10)+" -- "+format, args...)
 	return timeKey
 }
 
 // func W prints a warning - something went bad, but the process can be continued. Only allowed in special cases
 func W(tag Tag, format string, args ...interface{}) int64 {
 	timeKey := time.Now().UnixNano()
-	l.P(KYEL+"WARN", tag, strconv.FormatInt(timeKey, 10)+" -- "+format, args...)
+	if !enabled(tag, LevelWarn) {
+		return timeKey
+	}
+	emit(LevelWarn, "WARN", tag, "", strconv.FormatInt(timeKey, 10)+" -- "+format, args...)
 	return timeKey
 }
 
 // func E prints an error, with stacktrace - IMMEDIATELY FIX THIS!
 func E(tag Tag, format string, args ...interface{}) int64 {
+	return logError(1, tag, format, args...)
+}
+
+// logError is E's skip-aware implementation, so callers that wrap E (e.g.
+// Logger.E) can account for their own extra stack frame.
+func logError(skip int, tag Tag, format string, args ...interface{}) int64 {
 	timeKey := time.Now().UnixNano()
-	format = fmt.Sprintf(format, args...)
-	format += fmt.Sprintf("\n StackTrace : %v", string(godbg.Stack()))
-	l.P(KRED+"ERROR", tag, strconv.FormatInt(timeKey, 10)+" -- "+format)
+	if !enabled(tag, LevelError) {
+		return timeKey
+	}
+	stack := captureStack(skip)
+	emit(LevelError, "ERROR", tag, stack, strconv.FormatInt(timeKey, 10)+" -- "+format, args...)
 	return timeKey
 }
 
 // func WTF prints a WTF - "What a terrible failure"
 func WTF(tag Tag, format string, args ...interface{}) int64 {
 	timeKey := time.Now().UnixNano()
-	l.P(KMAG+"WTF", tag, strconv.FormatInt(timeKey, 10)+" -- "+format, args...)
-	return timeKey
-}
-
-// func P is used to add a prepositon & tag to the given logmessage & reset colors
-func (d debug) P(preposition string, tag Tag, format string, args ...interface{}) {
-	if d {
-		log.Printf(preposition+"/"+string(tag)+" : "+format+KRESET, args...)
+	if !enabled(tag, LevelError) {
+		return timeKey
 	}
+	emit(LevelError, "WTF", tag, "", strconv.FormatInt(timeKey, 10)+" -- "+format, args...)
+	return timeKey
 }
 
-// func GetRequest prints a http-Request, if we are in develop the full request, otherwise only Method & url without parameters
+// func GetRequest prints a http-Request, if we are in develop a redacted
+// snapshot of the full request, otherwise only Method & url without
+// parameters. It also stamps r with a correlation ID header if it doesn't
+// already carry one, so the request can be traced across log lines.
 func GetRequest(r *http.Request) interface{} {
-	if Develop {
-		return r
+	ensureCorrelationID(r)
+	if IsDevelop() {
+		return redactRequest(r)
 	} else {
-		return fmt.Sprintf("[%s] %q %v\n", r.Method, r.URL.Path)
-	}
-}
-
-// func P is used to add a prepositon & tag to the given logmessage & reset colors
-func (l lg) P(preposition string, tag Tag, format string, args ...interface{}) {
-	if l {
-		log.Printf(preposition+"/"+string(tag)+" : "+format+KRESET, args...)
-	}
-}
-
-// func P is used to add a prepositon & tag to the given logmessage & reset colors
-func (i info) P(preposition string, tag Tag, format string, args ...interface{}) {
-
-	if i {
-		log.Printf(preposition+"/"+string(tag)+" : "+format+KRESET, args...)
-	}
-}
-
-// func P is used to add a prepositon & tag to the given logmessage & reset colors
-func (v verbose) P(preposition string, tag Tag, format string, args ...interface{}) {
-
-	if v {
-		log.Printf(preposition+"/"+string(tag)+" : "+format+KRESET, args...)
+		return fmt.Sprintf("[%s] %q\n", r.Method, r.URL.Path)
 	}
 }
@@ -0,0 +1,74 @@
+package dbg
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+// Flusher is an optional interface a Backend can implement to drain any
+// buffered or async output. Flush calls it on every registered backend.
+type Flusher interface {
+	Flush() error
+}
+
+// Flush drains every registered backend that implements Flusher. Call it
+// before process exit if you bypass F/Panic (e.g. os.Exit in your own code).
+func Flush() {
+	backendsMu.RLock()
+	bs := backends
+	backendsMu.RUnlock()
+
+	for _, b := range bs {
+		if f, ok := b.(Flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+// F logs a fatal-level message unconditionally, flushes every backend, then
+// calls os.Exit(1). Use it for errors the process cannot continue past.
+func F(tag Tag, format string, args ...interface{}) {
+	fatal(1, tag, format, args...)
+}
+
+// fatal is F's skip-aware implementation, so callers that wrap F (e.g.
+// Logger.F) can account for their own extra stack frame.
+func fatal(skip int, tag Tag, format string, args ...interface{}) {
+	stack := captureStack(skip)
+	emit(LevelError, "FATAL", tag, stack, format, args...)
+	Flush()
+	os.Exit(1)
+}
+
+// Panic logs a fatal-level message unconditionally, flushes every backend,
+// then panics with the formatted message.
+func Panic(tag Tag, format string, args ...interface{}) {
+	panicLog(1, tag, format, args...)
+}
+
+// panicLog is Panic's skip-aware implementation, so callers that wrap Panic
+// (e.g. Logger.Panic) can account for their own extra stack frame.
+func panicLog(skip int, tag Tag, format string, args ...interface{}) {
+	stack := captureStack(skip)
+	msg := fmt.Sprintf(format, args...)
+	emit(LevelError, "PANIC", tag, stack, format, args...)
+	Flush()
+	panic(msg)
+}
+
+// FlushOnSignal starts a goroutine that flushes every backend and calls
+// os.Exit(1) when one of sigs is received. dbg never installs a signal
+// handler on its own - an application that owns its own graceful shutdown
+// on SIGTERM/SIGINT would otherwise race this goroutine and lose. Call this
+// explicitly only if you want dbg's signal handling instead of your own,
+// e.g. FlushOnSignal(os.Interrupt, syscall.SIGTERM).
+func FlushOnSignal(sigs ...os.Signal) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sigs...)
+	go func() {
+		<-c
+		Flush()
+		os.Exit(1)
+	}()
+}
@@ -0,0 +1,131 @@
+package dbg
+
+import "sync"
+
+// Level is a log severity/verbosity level, ordered from most critical (LevelError)
+// to most chatty (LevelVerbose). Higher values are more verbose.
+type Level int
+
+const (
+	LevelError Level = iota
+	LevelWarn
+	LevelInfo
+	LevelDebug
+	LevelVerbose
+)
+
+// String returns the human-readable name of l.
+func (l Level) String() string {
+	switch l {
+	case LevelError:
+		return "ERROR"
+	case LevelWarn:
+		return "WARN"
+	case LevelInfo:
+		return "INFO"
+	case LevelDebug:
+		return "DEBUG"
+	case LevelVerbose:
+		return "VERBOSE"
+	default:
+		return "VERBOSE+"
+	}
+}
+
+var (
+	stateMu sync.RWMutex
+
+	// level is the default, package-wide verbosity threshold. It replaces the
+	// old compile-time Debugging/v/i/l consts, and can be changed at runtime.
+	level = LevelVerbose
+
+	// develop replaces the old compile-time Develop const.
+	develop = true
+
+	// tagLevels holds per-tag overrides of level, keyed by Tag.
+	tagLevels = map[Tag]Level{}
+)
+
+// SetLevel sets the default verbosity threshold used by tags that have no
+// per-tag override set via SetTagLevel.
+func SetLevel(l Level) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	level = l
+}
+
+// CurrentLevel returns the current default verbosity threshold.
+func CurrentLevel() Level {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return level
+}
+
+// SetDevelop toggles develop mode at runtime. SHOULD NEVER EVER be set in
+// Prod, it is used to disable security-relevant features in the using library.
+func SetDevelop(on bool) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	develop = on
+}
+
+// IsDevelop reports whether develop mode is currently enabled.
+func IsDevelop() bool {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return develop
+}
+
+// SetTagLevel sets a per-tag verbosity threshold that overrides the default
+// level for log calls using that tag, e.g. SetTagLevel("auth", LevelDebug).
+func SetTagLevel(tag Tag, l Level) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	tagLevels[tag] = l
+}
+
+// TagLevel returns the per-tag override for tag, if one was set via
+// SetTagLevel.
+func TagLevel(tag Tag) (l Level, ok bool) {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	l, ok = tagLevels[tag]
+	return l, ok
+}
+
+// enabled reports whether a log call at level l for tag should be emitted,
+// consulting the per-tag override before falling back to the default level.
+func enabled(tag Tag, l Level) bool {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	if tl, ok := tagLevels[tag]; ok {
+		return l <= tl
+	}
+	return l <= level
+}
+
+// Verbose is a guard returned by V, carrying the requested verbosity level,
+// following the glog convention of gating expensive debug calls behind a
+// verbosity check, e.g. dbg.V(dbg.LevelDebug).D(tag, "big dump: %v", huge).
+// The tag's effective level - per-tag override if set via SetTagLevel,
+// otherwise the package default - is what ultimately decides whether D
+// fires; V itself does no gating since it doesn't know the tag yet.
+type Verbose Level
+
+// V returns a guard for verbosity level n; call its D method with the tag
+// that determines whether n is actually enabled. Use it to guard hot-path
+// verbose logging that would otherwise pay the formatting cost even when
+// the message is discarded.
+func V(n Level) Verbose {
+	return Verbose(n)
+}
+
+// D logs a message at v's verbosity level if tag's effective level allows
+// it, short-circuiting before formatting otherwise.
+func (v Verbose) D(tag Tag, format string, args ...interface{}) {
+	n := Level(v)
+	if !enabled(tag, n) {
+		return
+	}
+	emit(n, "VERBOSE", tag, "", format, args...)
+}
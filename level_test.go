@@ -0,0 +1,38 @@
+package dbg
+
+import "testing"
+
+// TestVerbosityLevelGating verifies that V(n) actually gates on the
+// verbosity level n passed to it, not a hardcoded LevelDebug.
+func TestVerbosityLevelGating(t *testing.T) {
+	defer SetLevel(LevelVerbose)
+	SetLevel(LevelWarn)
+
+	rec := CaptureForTest(t)
+	V(LevelError).D("svc", "at or below current level")
+	V(LevelVerbose).D("svc", "above current level")
+
+	if rec.Count(LevelError) != 1 {
+		t.Errorf("want 1 record at LevelError, got %d", rec.Count(LevelError))
+	}
+	if rec.Count(LevelVerbose) != 0 {
+		t.Errorf("want 0 records at LevelVerbose, got %d", rec.Count(LevelVerbose))
+	}
+}
+
+// TestVerbosityPerTagOverride verifies glog-style -vmodule-equivalent
+// per-tag verbosity: a tag's own SetTagLevel threshold, not the package
+// default, decides whether V(n).D fires for that tag.
+func TestVerbosityPerTagOverride(t *testing.T) {
+	defer SetTagLevel("auth", LevelVerbose)
+	SetTagLevel("auth", LevelWarn)
+
+	rec := CaptureForTest(t)
+	V(LevelWarn).D("auth", "at tag threshold")
+	V(LevelVerbose).D("auth", "above tag threshold")
+
+	rec.AssertContains(LevelWarn, "auth", "at tag threshold")
+	if rec.Count(LevelVerbose) != 0 {
+		t.Errorf("tag override should suppress verbosity above LevelWarn, got %d records", rec.Count(LevelVerbose))
+	}
+}
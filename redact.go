@@ -0,0 +1,230 @@
+package dbg
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Redactor lets a value control how it appears in log output and Records,
+// e.g. to mask a password or token field before it ever reaches a backend.
+type Redactor interface {
+	Redacted() interface{}
+}
+
+// redactArgs returns a copy of args with every Redactor replaced by its
+// Redacted() form.
+func redactArgs(args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	for i, a := range args {
+		if red, ok := a.(Redactor); ok {
+			out[i] = red.Redacted()
+		} else {
+			out[i] = a
+		}
+	}
+	return out
+}
+
+const redactedPlaceholder = "[REDACTED]"
+
+var (
+	redactMu        sync.RWMutex
+	sensitiveFields = map[string]bool{
+		"authorization": true,
+		"cookie":        true,
+		"set-cookie":    true,
+		"password":      true,
+		"token":         true,
+		"secret":        true,
+		"api_key":       true,
+		"apikey":        true,
+	}
+	maxBodyDump = 4096
+)
+
+// SetSensitiveFields replaces the set of header/query/body field names
+// (matched case-insensitively) that GetRequest masks before returning a
+// request.
+func SetSensitiveFields(fields []string) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	sensitiveFields = make(map[string]bool, len(fields))
+	for _, f := range fields {
+		sensitiveFields[strings.ToLower(f)] = true
+	}
+}
+
+// SetMaxBodyDump sets the maximum number of request body bytes GetRequest
+// will include; anything beyond n is truncated.
+func SetMaxBodyDump(n int) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	maxBodyDump = n
+}
+
+func isSensitiveField(name string) bool {
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+	return sensitiveFields[strings.ToLower(name)]
+}
+
+func currentMaxBodyDump() int {
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+	return maxBodyDump
+}
+
+// RedactedRequest is the safe, loggable snapshot of an *http.Request
+// returned by GetRequest in develop mode: headers, query parameters and
+// JSON body fields matching the sensitive field list are masked, and the
+// body is capped at SetMaxBodyDump bytes.
+type RedactedRequest struct {
+	Method        string
+	Path          string
+	Header        http.Header
+	Query         url.Values
+	Body          string
+	BodyTruncated bool
+}
+
+func redactHeader(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, vs := range h {
+		if isSensitiveField(k) {
+			out[k] = []string{redactedPlaceholder}
+			continue
+		}
+		cp := make([]string, len(vs))
+		copy(cp, vs)
+		out[k] = cp
+	}
+	return out
+}
+
+func redactQuery(q url.Values) url.Values {
+	out := make(url.Values, len(q))
+	for k, vs := range q {
+		if isSensitiveField(k) {
+			out[k] = []string{redactedPlaceholder}
+			continue
+		}
+		cp := make([]string, len(vs))
+		copy(cp, vs)
+		out[k] = cp
+	}
+	return out
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if isSensitiveField(k) {
+				t[k] = redactedPlaceholder
+			} else {
+				t[k] = redactJSON(val)
+			}
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactJSON(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// bodyReadCap is how much of the body we'll read to find field boundaries
+// when the configured SetMaxBodyDump limit is smaller; a larger
+// SetMaxBodyDump limit raises the read to that limit. Reading stops at the
+// larger of the two, so a huge body can't be used to exhaust memory while
+// we look for JSON/form fields to redact.
+const bodyReadCap = 1 << 20 // 1MiB
+
+// redactBody reads and masks r.Body, restoring it afterwards so downstream
+// handlers can still read it. Redaction runs against the full (up to the
+// larger of bodyReadCap and SetMaxBodyDump) body before the configured
+// SetMaxBodyDump limit is applied,
+// so a field near the end of an oversized body still gets masked instead of
+// surviving in the truncated, unparsed tail.
+func redactBody(r *http.Request) (body string, truncated bool) {
+	if r.Body == nil {
+		return "", false
+	}
+	readLimit := bodyReadCap
+	if limit := currentMaxBodyDump(); limit > readLimit {
+		readLimit = limit
+	}
+	orig := r.Body
+	raw, _ := io.ReadAll(io.LimitReader(orig, int64(readLimit)+1))
+	// Put back what was read, followed by whatever of the body was not read.
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(raw), orig), orig}
+
+	bodyTruncated := len(raw) > readLimit
+	if bodyTruncated {
+		raw = raw[:readLimit]
+	}
+
+	redacted, ok := redactJSONBody(raw)
+	if !ok {
+		redacted, ok = redactFormBody(r, raw)
+	}
+	if !ok {
+		redacted = string(raw)
+	}
+
+	limit := currentMaxBodyDump()
+	if len(redacted) > limit {
+		return redacted[:limit], true
+	}
+	return redacted, bodyTruncated
+}
+
+// redactJSONBody masks sensitive keys in a JSON object/array body.
+func redactJSONBody(raw []byte) (body string, ok bool) {
+	var parsed interface{}
+	if json.Unmarshal(raw, &parsed) != nil {
+		return "", false
+	}
+	out, err := json.Marshal(redactJSON(parsed))
+	if err != nil {
+		return "", false
+	}
+	return string(out), true
+}
+
+// redactFormBody masks sensitive fields in an application/x-www-form-urlencoded
+// body - the classic `password=hunter2&token=abc` login-form shape.
+func redactFormBody(r *http.Request, raw []byte) (body string, ok bool) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return "", false
+	}
+	values, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return "", false
+	}
+	return redactQuery(values).Encode(), true
+}
+
+// redactRequest builds the masked snapshot used by GetRequest.
+func redactRequest(r *http.Request) RedactedRequest {
+	body, truncated := redactBody(r)
+	return RedactedRequest{
+		Method:        r.Method,
+		Path:          r.URL.Path,
+		Header:        redactHeader(r.Header),
+		Query:         redactQuery(r.URL.Query()),
+		Body:          body,
+		BodyTruncated: truncated,
+	}
+}
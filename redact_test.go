@@ -0,0 +1,77 @@
+package dbg
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestRedactBodyFormEncoded verifies that an application/x-www-form-urlencoded
+// body - the classic login-form shape - gets its sensitive fields masked the
+// same way a JSON body does, and that the request body is still readable
+// afterwards by whatever handler called GetRequest.
+func TestRedactBodyFormEncoded(t *testing.T) {
+	defer SetDevelop(IsDevelop())
+	SetDevelop(true)
+
+	const raw = "username=alice&password=hunter2&token=abc123"
+	req, err := http.NewRequest("POST", "http://example.com/login", strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	got := GetRequest(req)
+	rr, ok := got.(RedactedRequest)
+	if !ok {
+		t.Fatalf("want RedactedRequest, got %T", got)
+	}
+
+	if strings.Contains(rr.Body, "hunter2") || strings.Contains(rr.Body, "abc123") {
+		t.Errorf("form body leaked a sensitive value: %s", rr.Body)
+	}
+	if !strings.Contains(rr.Body, "alice") {
+		t.Errorf("non-sensitive field should survive redaction: %s", rr.Body)
+	}
+
+	restored, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(restored) != raw {
+		t.Errorf("request body should be restored for downstream handlers, got %q", restored)
+	}
+}
+
+// TestRedactBodyJSONOversized verifies that a JSON body exceeding
+// SetMaxBodyDump still gets its sensitive fields masked - redaction must run
+// against the full body, not the already byte-capped (and therefore
+// unparseable) tail.
+func TestRedactBodyJSONOversized(t *testing.T) {
+	defer SetDevelop(IsDevelop())
+	SetDevelop(true)
+
+	defer SetMaxBodyDump(4096)
+	SetMaxBodyDump(20)
+
+	const raw = `{"note":"hi","password":"hunter2-secret-value","x":1}`
+	req, err := http.NewRequest("POST", "http://example.com/login", strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	got := GetRequest(req)
+	rr, ok := got.(RedactedRequest)
+	if !ok {
+		t.Fatalf("want RedactedRequest, got %T", got)
+	}
+
+	if strings.Contains(rr.Body, "hunter2-secret-value") {
+		t.Errorf("oversized JSON body leaked the password in plaintext: %s", rr.Body)
+	}
+	if !rr.BodyTruncated {
+		t.Errorf("body exceeding SetMaxBodyDump should be reported truncated")
+	}
+}
@@ -0,0 +1,50 @@
+package dbg
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	stackMu       sync.RWMutex
+	maxStackDepth = 32
+)
+
+// SetMaxStackDepth sets how many stack frames E/F/Panic capture.
+func SetMaxStackDepth(n int) {
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	maxStackDepth = n
+}
+
+func currentMaxStackDepth() int {
+	stackMu.RLock()
+	defer stackMu.RUnlock()
+	return maxStackDepth
+}
+
+// captureStack returns a formatted stack trace of up to the configured max
+// depth, starting at the caller of the dbg function that asked for it -
+// skipping captureStack's own frame, its immediate caller (e.g. logError), and
+// skip additional frames above that.
+func captureStack(skip int) string {
+	depth := currentMaxStackDepth()
+	pc := make([]uintptr, depth)
+	n := runtime.Callers(skip+3, pc)
+	if n == 0 {
+		return ""
+	}
+
+	frames := runtime.CallersFrames(pc[:n])
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
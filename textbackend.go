@@ -0,0 +1,66 @@
+package dbg
+
+import (
+	"io"
+	"log"
+	"os"
+)
+
+// labelColors maps a Record's Label to the ANSI color it was historically
+// printed with.
+var labelColors = map[string]string{
+	"DEBUG":   KNRM,
+	"VERBOSE": KFNT,
+	"INFO":    KGRN,
+	"WARN":    KYEL,
+	"ERROR":   KRED,
+	"WTF":     KMAG,
+	"FATAL":   KRED,
+	"PANIC":   KMAG,
+}
+
+// TextBackend is the built-in Backend reproducing the package's original
+// "LABEL/tag : msg" console output. It auto-disables ANSI colors when its
+// writer isn't a TTY, so output stays clean in containerized/non-TTY
+// environments (files, piped logs, CI) where raw escape codes just corrupt
+// the log.
+type TextBackend struct {
+	logger *log.Logger
+	color  bool
+}
+
+// NewTextBackend returns a TextBackend writing to w, with colors enabled
+// only if w is a terminal.
+func NewTextBackend(w io.Writer) *TextBackend {
+	return &TextBackend{
+		logger: log.New(w, "", log.LstdFlags),
+		color:  isTerminal(w),
+	}
+}
+
+// Log implements Backend.
+func (t *TextBackend) Log(r Record) {
+	msg := r.Msg
+	if r.Stack != "" {
+		msg += "\n StackTrace : " + r.Stack
+	}
+	line := r.Label + "/" + string(r.Tag) + " : " + msg
+	if t.color {
+		line = labelColors[r.Label] + line + KRESET
+	}
+	t.logger.Print(line)
+}
+
+// isTerminal reports whether w is a character device, i.e. an interactive
+// terminal rather than a file or pipe.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
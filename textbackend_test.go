@@ -0,0 +1,49 @@
+package dbg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestTextBackendNonTTYHasNoColor verifies that a TextBackend writing to a
+// non-terminal (here, a bytes.Buffer) never emits ANSI color codes, since
+// isTerminal can't report true for anything but an *os.File character
+// device.
+func TestTextBackendNonTTYHasNoColor(t *testing.T) {
+	var buf bytes.Buffer
+	tb := NewTextBackend(&buf)
+
+	tb.Log(Record{Label: "ERROR", Tag: "svc", Msg: "boom"})
+
+	out := buf.String()
+	if strings.Contains(out, KRED) || strings.Contains(out, KRESET) {
+		t.Errorf("non-TTY output should not contain ANSI color codes, got %q", out)
+	}
+	if !strings.Contains(out, "ERROR/svc : boom") {
+		t.Errorf("output should contain the formatted label/tag/msg line, got %q", out)
+	}
+}
+
+// TestTextBackendIncludesStack verifies that a non-empty Record.Stack is
+// appended to the logged line.
+func TestTextBackendIncludesStack(t *testing.T) {
+	var buf bytes.Buffer
+	tb := NewTextBackend(&buf)
+
+	tb.Log(Record{Label: "ERROR", Tag: "svc", Msg: "boom", Stack: "fake.go:1"})
+
+	out := buf.String()
+	if !strings.Contains(out, "StackTrace : fake.go:1") {
+		t.Errorf("output should include the stack trace, got %q", out)
+	}
+}
+
+// TestIsTerminalNonFile verifies that isTerminal reports false for a writer
+// that isn't an *os.File at all.
+func TestIsTerminalNonFile(t *testing.T) {
+	var buf bytes.Buffer
+	if isTerminal(&buf) {
+		t.Errorf("a bytes.Buffer is never a terminal")
+	}
+}